@@ -0,0 +1,149 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// https://developer.zendesk.com/api-reference/ticketing/business-rules/macros/
+func dataSourceZendeskMacro() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up a macro, either by its `id` or its exact `title`. Useful for referencing a macro that is managed outside of this Terraform configuration, such as a shared system macro.",
+		ReadContext: dataSourceZendeskMacroRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description:  "The ID of the macro to look up. Conflicts with `title`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"id", "title"},
+			},
+			"title": {
+				Description:  "The exact title of the macro to look up. Conflicts with `id`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"id", "title"},
+			},
+			"url": {
+				Description: "The URL for this macro.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"description": {
+				Description: "The description of the macro.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"position": {
+				Description: "The position of the macro.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"restriction": macroRestrictionDataSourceSchema(),
+			"active": {
+				Description: "The active status of the macro.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"actions": macroActionsDataSourceSchema(),
+		},
+	}
+}
+
+// dataSourceZendeskMacroRead looks up a macro by id or title
+func dataSourceZendeskMacroRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zd := meta.(*client.Client)
+	return readMacroDataSource(ctx, d, zd)
+}
+
+// readMacroDataSource looks up a macro by id or title
+func readMacroDataSource(ctx context.Context, d identifiableGetterSetter, zd client.MacroAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if v, ok := d.GetOk("id"); ok {
+		id, err := strconv.ParseInt(v.(string), 10, 64)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("could not parse macro id %s: %v", v, err))
+		}
+
+		var macro client.Macro
+		if err := withMacroRateLimit(ctx, func() error {
+			var rateLimitErr error
+			macro, rateLimitErr = zd.GetMacro(ctx, id)
+			return rateLimitErr
+		}); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(strconv.FormatInt(macro.ID, 10))
+		if err := marshalMacro(macro, d); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diags
+	}
+
+	title := d.Get("title").(string)
+
+	macros, err := listAllMacros(ctx, zd, &client.MacroListOptions{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var found *client.Macro
+	for i, macro := range macros {
+		if macro.Title == title {
+			found = &macros[i]
+			break
+		}
+	}
+
+	if found == nil {
+		return diag.FromErr(fmt.Errorf("no macro found with title %q", title))
+	}
+
+	d.SetId(strconv.FormatInt(found.ID, 10))
+	if err := marshalMacro(*found, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// listAllMacros pages through client.MacroAPI.GetMacros until it has
+// collected every macro matching opts.
+func listAllMacros(ctx context.Context, zd client.MacroAPI, opts *client.MacroListOptions) ([]client.Macro, error) {
+	var macros []client.Macro
+
+	page := 1
+	for {
+		opts.Page = page
+		if opts.PerPage == 0 {
+			opts.PerPage = 100
+		}
+
+		var result []client.Macro
+		var p client.Page
+		if err := withMacroRateLimit(ctx, func() error {
+			var rateLimitErr error
+			result, p, rateLimitErr = zd.GetMacros(ctx, opts)
+			return rateLimitErr
+		}); err != nil {
+			return nil, err
+		}
+
+		macros = append(macros, result...)
+
+		if p.NextPage == nil {
+			break
+		}
+		page++
+	}
+
+	return macros, nil
+}
@@ -0,0 +1,92 @@
+package zendesk
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	"github.com/nukosuke/go-zendesk/zendesk"
+	"github.com/nukosuke/go-zendesk/zendesk/mock"
+)
+
+func TestReadMacroDataSourceByID(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	gs := &identifiableMapGetterSetter{
+		mapGetterSetter: mapGetterSetter{
+			"id": "1234",
+		},
+	}
+
+	macro := zendesk.Macro{
+		ID:    1234,
+		Title: "foobar",
+	}
+
+	m.EXPECT().GetMacro(Any(), Eq(int64(1234))).Return(macro, nil)
+	if diags := readMacroDataSource(context.Background(), gs, m); len(diags) != 0 {
+		t.Fatal("readMacroDataSource returned an error")
+	}
+
+	if v := gs.Id(); v != "1234" {
+		t.Fatalf("readMacroDataSource did not set id. Id was %s", v)
+	}
+}
+
+func TestReadMacroDataSourceByTitle(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	gs := &identifiableMapGetterSetter{
+		mapGetterSetter: mapGetterSetter{
+			"title": "foobar",
+		},
+	}
+
+	macros := []zendesk.Macro{
+		{ID: 1, Title: "other"},
+		{ID: 1234, Title: "foobar"},
+	}
+
+	m.EXPECT().GetMacros(Any(), Any()).Return(macros, zendesk.Page{}, nil)
+	if diags := readMacroDataSource(context.Background(), gs, m); len(diags) != 0 {
+		t.Fatal("readMacroDataSource returned an error")
+	}
+
+	if v := gs.Id(); v != "1234" {
+		t.Fatalf("readMacroDataSource did not resolve title to the expected id. Id was %s", v)
+	}
+}
+
+func TestReadMacrosDataSource(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	gs := &identifiableMapGetterSetter{
+		mapGetterSetter: make(mapGetterSetter),
+	}
+
+	macros := []zendesk.Macro{
+		{ID: 1, Title: "foo"},
+		{ID: 2, Title: "bar"},
+	}
+
+	m.EXPECT().GetMacros(Any(), Any()).Return(macros, zendesk.Page{}, nil)
+	if diags := readMacrosDataSource(context.Background(), gs, m); len(diags) != 0 {
+		t.Fatal("readMacrosDataSource returned an error")
+	}
+
+	out, ok := gs.mapGetterSetter["macros"].([]map[string]interface{})
+	if !ok || len(out) != 2 {
+		t.Fatalf("readMacrosDataSource did not set macros. Value was %v", gs.mapGetterSetter["macros"])
+	}
+
+	if v := out[0]["id"]; v != strconv.FormatInt(macros[0].ID, 10) {
+		t.Fatalf("macro 0 had incorrect id %v", v)
+	}
+}
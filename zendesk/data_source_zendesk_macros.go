@@ -0,0 +1,130 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// https://developer.zendesk.com/api-reference/ticketing/business-rules/macros/
+func dataSourceZendeskMacros() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up every macro matching the given filters. Useful for enumerating macros managed outside of this Terraform configuration.",
+		ReadContext: dataSourceZendeskMacrosRead,
+
+		Schema: map[string]*schema.Schema{
+			"active": {
+				Description: "Filter macros by active status, as the raw query value Zendesk expects, e.g. `true` or `false`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"access": {
+				Description: "Filter macros by who can access them, e.g. `personal`, `shared`, or a group/permission-group reference.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"category": {
+				Description: "Filter macros by category id.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"group_id": {
+				Description: "Filter macros by the id of the group they are restricted to.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"include": {
+				Description: "Side-load additional macro data, e.g. `usage_1h`, `usage_24h`, `usage_7d`, or `usage_30d`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"macros": {
+				Description: "The macros matching the given filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the macro.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"url": {
+							Description: "The URL for this macro.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"title": {
+							Description: "The title of the macro.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "The description of the macro.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"position": {
+							Description: "The position of the macro.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"restriction": macroRestrictionDataSourceSchema(),
+						"active": {
+							Description: "The active status of the macro.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"actions": macroActionsDataSourceSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceZendeskMacrosRead looks up every macro matching the configured filters
+func dataSourceZendeskMacrosRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zd := meta.(*client.Client)
+	return readMacrosDataSource(ctx, d, zd)
+}
+
+// readMacrosDataSource looks up every macro matching the configured filters
+func readMacrosDataSource(ctx context.Context, d identifiableGetterSetter, zd client.MacroAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	opts := &client.MacroListOptions{
+		Access:   d.Get("access").(string),
+		Active:   d.Get("active").(string),
+		Category: d.Get("category").(int),
+		GroupID:  d.Get("group_id").(int),
+		Include:  d.Get("include").(string),
+	}
+
+	macros, err := listAllMacros(ctx, zd, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	out := make([]map[string]interface{}, len(macros))
+	for i, macro := range macros {
+		flattened, err := flattenMacro(macro)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("could not marshal macro %d: %v", macro.ID, err))
+		}
+
+		flattened["id"] = strconv.FormatInt(macro.ID, 10)
+		out[i] = flattened
+	}
+
+	d.SetId(fmt.Sprintf("%d", len(out)))
+	if err := d.Set("macros", out); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
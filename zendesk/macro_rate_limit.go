@@ -0,0 +1,106 @@
+package zendesk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	client "github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// macroRateLimiter paces every macro API call made by this provider and
+// backs off when Zendesk responds with 429 Too Many Requests, so applying a
+// large zendesk_macro_set survives Zendesk's rate limits without
+// per-resource retry tuning.
+var macroRateLimiter = newMacroAPIRateLimiter()
+
+const (
+	macroRateLimitMinInterval = 100 * time.Millisecond
+	macroRateLimitMaxRetries  = 5
+	macroRateLimitBackoffWait = 2 * time.Second
+)
+
+type macroAPIRateLimiter struct {
+	mu       sync.Mutex
+	nextCall time.Time
+}
+
+func newMacroAPIRateLimiter() *macroAPIRateLimiter {
+	return &macroAPIRateLimiter{}
+}
+
+// throttle blocks until it is this caller's turn to make a macro API call.
+func (l *macroAPIRateLimiter) throttle(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	start := l.nextCall
+	if start.Before(now) {
+		start = now
+	}
+	wait := start.Sub(now)
+	l.nextCall = start.Add(macroRateLimitMinInterval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// backoff waits out a 429 response from Zendesk and reports whether the
+// caller should retry its request. client.Error does not expose the
+// response's Retry-After header, so every 429 waits the same fixed interval
+// rather than the one Zendesk actually asked for.
+func (l *macroAPIRateLimiter) backoff(ctx context.Context, err error, attempt int) (bool, error) {
+	var zdErr client.Error
+	if !errors.As(err, &zdErr) || zdErr.Status() != http.StatusTooManyRequests {
+		return false, nil
+	}
+
+	if attempt >= macroRateLimitMaxRetries {
+		return false, nil
+	}
+
+	l.mu.Lock()
+	l.nextCall = time.Now().Add(macroRateLimitBackoffWait)
+	l.mu.Unlock()
+
+	select {
+	case <-time.After(macroRateLimitBackoffWait):
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// withMacroRateLimit runs fn, throttling calls to stay under Zendesk's rate
+// limits and retrying with backoff when Zendesk returns 429 Too Many
+// Requests.
+func withMacroRateLimit(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		if err := macroRateLimiter.throttle(ctx); err != nil {
+			return err
+		}
+
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		retry, waitErr := macroRateLimiter.backoff(ctx, err, attempt)
+		if waitErr != nil {
+			return waitErr
+		}
+		if !retry {
+			return err
+		}
+	}
+}
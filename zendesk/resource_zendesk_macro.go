@@ -2,11 +2,13 @@ package zendesk
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	client "github.com/nukosuke/go-zendesk/zendesk"
 )
 
@@ -22,6 +24,15 @@ func resourceZendeskMacro() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceZendeskMacroResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceZendeskMacroStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"url": {
 				Description: "The URL for this macro.",
@@ -44,9 +55,31 @@ func resourceZendeskMacro() *schema.Resource {
 				Optional:    true,
 			},
 			"restriction": {
-				Description: "The restriction of the macro.",
-				Type:        schema.TypeString,
+				Description: "The group or user this macro is restricted to, if any.",
+				Type:        schema.TypeList,
 				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description:  "The type of restriction: `Group` or `User`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"Group", "User"}, false),
+						},
+						"id": {
+							Description: "The id of the single group or user this macro is restricted to.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"ids": {
+							Description: "The ids of the groups or users this macro is restricted to, when restricted to more than one.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
 			},
 			"active": {
 				Description: "The active status of the macro.",
@@ -54,47 +87,242 @@ func resourceZendeskMacro() *schema.Resource {
 				Optional:    true,
 				Default:     true,
 			},
-			"actions": {
-				Description: "The actions of the macro.",
-				Type:        schema.TypeList,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"field": {
-							Description: "The field of the action.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"value": {
-							Description: "The value of the action.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
+			"actions": macroActionsSchema(),
+		},
+	}
+}
+
+// macroActionsSchema returns the actions list schema shared by the
+// zendesk_macro resource and zendesk_macro_set.
+func macroActionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The actions of the macro.",
+		Type:        schema.TypeList,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": {
+					Description: "The field of the action.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"value": {
+					Description: "The value of the action. Used when `value_format` is `string` (a plain scalar) or `json` (a raw JSON-encoded document, e.g. for `side_conversation` payloads). Ignored when `value_format` is `list`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"value_list": {
+					Description: "The list of values for the action. Used when `value_format` is `list`, e.g. for `set_tags`, `add_tags`, and `remove_tags`.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"value_format": {
+					Description:  "The shape of the action's value: `string` for a plain scalar, `list` to read `value_list` instead, or `json` to parse `value` as a raw JSON document. Defaults to `string`.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "string",
+					ValidateFunc: validation.StringInSlice([]string{"string", "list", "json"}, false),
 				},
 			},
 		},
 	}
 }
 
-// marshalMacro encodes the provided macro into the provided resource data
-func marshalMacro(field client.Macro, d identifiableGetterSetter) error {
-	fields := map[string]interface{}{
+// macroActionsDataSourceSchema returns the actions list schema shared by the
+// zendesk_macro and zendesk_macros data sources.
+// It mirrors macroActionsSchema's fields, but Computed rather than
+// Required/Optional.
+func macroActionsDataSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The actions of the macro.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": {
+					Description: "The field of the action.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"value": {
+					Description: "The value of the action.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"value_list": {
+					Description: "The list of values for the action, when `value_format` is `list`.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"value_format": {
+					Description: "The shape of the action's value: `string`, `list`, or `json`.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// macroRestrictionDataSourceSchema returns the restriction block schema
+// shared by the zendesk_macro and zendesk_macros data sources. It mirrors
+// the zendesk_macro resource's restriction block, but Computed rather than
+// Optional.
+func macroRestrictionDataSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The group or user this macro is restricted to, if any.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Description: "The type of restriction: `Group` or `User`.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"id": {
+					Description: "The id of the single group or user this macro is restricted to.",
+					Type:        schema.TypeInt,
+					Computed:    true,
+				},
+				"ids": {
+					Description: "The ids of the groups or users this macro is restricted to, when restricted to more than one.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeInt},
+				},
+			},
+		},
+	}
+}
+
+// resourceZendeskMacroResourceV0 returns the schema as it existed before
+// restriction became a structured block, for use by the state upgrader.
+func resourceZendeskMacroResourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"restriction": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// resourceZendeskMacroStateUpgradeV0 migrates a macro's restriction from the
+// plain string it used to be into the structured { type, id, ids } block it
+// is today. Legacy values are carried over as the new block's "type", unless
+// they are not one of the values that field now validates against, in which
+// case the restriction is dropped rather than upgraded into a value that
+// would fail every subsequent plan/apply.
+func resourceZendeskMacroStateUpgradeV0(_ context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	raw, ok := rawState["restriction"].(string)
+	if !ok || (raw != "Group" && raw != "User") {
+		rawState["restriction"] = []interface{}{}
+		return rawState, nil
+	}
+
+	rawState["restriction"] = []interface{}{
+		map[string]interface{}{
+			"type": raw,
+			"id":   0,
+			"ids":  []interface{}{},
+		},
+	}
+
+	return rawState, nil
+}
+
+// flattenMacroRestriction converts a macro's API restriction, a structured
+// `{ type, id, ids }` object, into the single-item list used by the
+// restriction schema.
+func flattenMacroRestriction(value interface{}) ([]map[string]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	raw, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("macro restriction had an unexpected shape: %v", value)
+	}
+
+	restriction := map[string]interface{}{}
+
+	if t, ok := raw["type"].(string); ok {
+		restriction["type"] = t
+	}
+
+	if id, ok := raw["id"].(float64); ok {
+		restriction["id"] = int(id)
+	}
+
+	if rawIDs, ok := raw["ids"].([]interface{}); ok {
+		ids := make([]int, 0, len(rawIDs))
+		for _, rawID := range rawIDs {
+			if id, ok := rawID.(float64); ok {
+				ids = append(ids, int(id))
+			}
+		}
+		restriction["ids"] = ids
+	}
+
+	return []map[string]interface{}{restriction}, nil
+}
+
+// flattenMacroActionValue converts a macro action's API value into the
+// string/value_list/value_format triple used by the actions schema. The
+// underlying client models a macro action's value as a single string, so
+// there is no way to tell, once a macro has round-tripped through the API,
+// whether it was originally written with value_list or value_format =
+// "json" - reads always report it back as a plain "string" value.
+func flattenMacroActionValue(value string) (format, strValue string, listValue []string, err error) {
+	return "string", value, nil, nil
+}
+
+// flattenMacro converts a macro into the flat map representation shared by
+// the zendesk_macro resource and its data sources.
+func flattenMacro(field client.Macro) (map[string]interface{}, error) {
+	actions := make([]map[string]interface{}, len(field.Actions))
+	for i, action := range field.Actions {
+		format, value, valueList, err := flattenMacroActionValue(action.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		actions[i] = map[string]interface{}{
+			"field":        action.Field,
+			"value":        value,
+			"value_list":   valueList,
+			"value_format": format,
+		}
+	}
+
+	restriction, err := flattenMacroRestriction(field.Restriction)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
 		"url":         field.URL,
 		"title":       field.Title,
 		"description": field.Description,
 		"position":    field.Position,
-		"restriction": field.Restriction,
+		"restriction": restriction,
 		"active":      field.Active,
-		"actions":     field.Actions,
-	}
+		"actions":     actions,
+	}, nil
+}
 
-	err := setSchemaFields(d, fields)
+// marshalMacro encodes the provided macro into the provided resource data
+func marshalMacro(field client.Macro, d identifiableGetterSetter) error {
+	fields, err := flattenMacro(field)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return setSchemaFields(d, fields)
 }
 
 // unmarshalMacro parses the provided ResourceData and returns a macro
@@ -130,23 +358,98 @@ func unmarshalMacro(d identifiableGetterSetter) (client.Macro, error) {
 	}
 
 	if v, ok := d.GetOk("restriction"); ok {
-		m.Restriction = v.(string)
+		m.Restriction = unmarshalMacroRestriction(v.([]interface{}))
 	}
 
 	if v, ok := d.GetOk("actions"); ok {
-		actions := v.([]interface{})
-		for _, action := range actions {
-			actionMap := action.(map[string]interface{})
-			m.Actions = append(m.Actions, client.MacroAction{
-				Field: actionMap["field"].(string),
-				Value: actionMap["value"].(string),
-			})
+		actions, err := unmarshalMacroActions(v.([]interface{}))
+		if err != nil {
+			return m, err
 		}
+		m.Actions = actions
 	}
 
 	return m, nil
 }
 
+// unmarshalMacroRestriction converts the restriction block from the
+// restriction schema into the structured value the API expects.
+func unmarshalMacroRestriction(raw []interface{}) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	r := raw[0].(map[string]interface{})
+	restriction := map[string]interface{}{}
+
+	if t, ok := r["type"].(string); ok && t != "" {
+		restriction["type"] = t
+	}
+
+	if id, ok := r["id"].(int); ok && id != 0 {
+		restriction["id"] = id
+	}
+
+	if rawIDs, ok := r["ids"].([]interface{}); ok && len(rawIDs) > 0 {
+		ids := make([]int, 0, len(rawIDs))
+		for _, rawID := range rawIDs {
+			ids = append(ids, rawID.(int))
+		}
+		restriction["ids"] = ids
+	}
+
+	return restriction
+}
+
+// unmarshalMacroActions converts the actions list from the actions schema
+// into macro actions, resolving each action's value_format into the single
+// string the underlying client's MacroAction.Value expects: value_list is
+// JSON-encoded into it, and value_format = "json" is validated as JSON and
+// passed through as-is.
+func unmarshalMacroActions(raw []interface{}) ([]client.MacroAction, error) {
+	actions := make([]client.MacroAction, 0, len(raw))
+	for _, action := range raw {
+		actionMap := action.(map[string]interface{})
+
+		format, _ := actionMap["value_format"].(string)
+		if format == "" {
+			format = "string"
+		}
+
+		var value string
+		switch format {
+		case "list":
+			rawList, _ := actionMap["value_list"].([]interface{})
+			values := make([]string, 0, len(rawList))
+			for _, rv := range rawList {
+				values = append(values, rv.(string))
+			}
+			encoded, err := json.Marshal(values)
+			if err != nil {
+				return nil, fmt.Errorf("could not encode value_list for macro action %s: %v", actionMap["field"], err)
+			}
+			value = string(encoded)
+		case "json":
+			if raw, _ := actionMap["value"].(string); raw != "" {
+				var parsed interface{}
+				if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+					return nil, fmt.Errorf("could not parse JSON value for macro action %s: %v", actionMap["field"], err)
+				}
+				value = raw
+			}
+		default:
+			value, _ = actionMap["value"].(string)
+		}
+
+		actions = append(actions, client.MacroAction{
+			Field: actionMap["field"].(string),
+			Value: value,
+		})
+	}
+
+	return actions, nil
+}
+
 // resourceZendeskMacroCreate creates a new macro
 func resourceZendeskMacroCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	zd := meta.(*client.Client)
@@ -163,7 +466,11 @@ func createMacro(ctx context.Context, d identifiableGetterSetter, zd client.Macr
 	}
 
 	// Actual API request
-	m, err = zd.CreateMacro(ctx, m)
+	err = withMacroRateLimit(ctx, func() error {
+		var rateLimitErr error
+		m, rateLimitErr = zd.CreateMacro(ctx, m)
+		return rateLimitErr
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -193,7 +500,12 @@ func readMacro(ctx context.Context, d identifiableGetterSetter, zd client.MacroA
 		return diag.FromErr(err)
 	}
 
-	macro, err := zd.GetMacro(ctx, id)
+	var macro client.Macro
+	err = withMacroRateLimit(ctx, func() error {
+		var rateLimitErr error
+		macro, rateLimitErr = zd.GetMacro(ctx, id)
+		return rateLimitErr
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -227,7 +539,11 @@ func updateMacro(ctx context.Context, d identifiableGetterSetter, zd client.Macr
 	}
 
 	// Actual API request
-	m, err = zd.UpdateMacro(ctx, id, m)
+	err = withMacroRateLimit(ctx, func() error {
+		var rateLimitErr error
+		m, rateLimitErr = zd.UpdateMacro(ctx, id, m)
+		return rateLimitErr
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -255,7 +571,9 @@ func deleteMacro(ctx context.Context, d identifiable, zd client.MacroAPI) diag.D
 		return diag.FromErr(err)
 	}
 
-	err = zd.DeleteMacro(ctx, id)
+	err = withMacroRateLimit(ctx, func() error {
+		return zd.DeleteMacro(ctx, id)
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
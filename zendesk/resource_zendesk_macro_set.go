@@ -0,0 +1,350 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// https://developer.zendesk.com/api-reference/ticketing/business-rules/macros/
+func resourceZendeskMacroSet() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a set of macros as a single unit. The underlying client has no bulk macro endpoints, so creates, updates, and deletes each go one request per macro, paced by the shared rate limiter.",
+		CreateContext: resourceZendeskMacroSetCreate,
+		ReadContext:   resourceZendeskMacroSetRead,
+		UpdateContext: resourceZendeskMacroSetUpdate,
+		DeleteContext: resourceZendeskMacroSetDelete,
+
+		Schema: map[string]*schema.Schema{
+			"macro": {
+				Description: "A macro belonging to this set.",
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID Zendesk assigned to this macro.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"title": {
+							Description: "The title of the macro.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"description": {
+							Description: "The description of the macro.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"position": {
+							Description: "The position of the macro.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"active": {
+							Description: "The active status of the macro.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"actions": macroActionsSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+// unmarshalMacroSetItems parses the macro blocks of a zendesk_macro_set
+// resource into individual macros, in configuration order.
+func unmarshalMacroSetItems(raw []interface{}) ([]client.Macro, error) {
+	macros := make([]client.Macro, len(raw))
+	for i, item := range raw {
+		itemMap := item.(map[string]interface{})
+
+		m := client.Macro{
+			Title:       itemMap["title"].(string),
+			Description: itemMap["description"].(string),
+			Position:    itemMap["position"].(int),
+			Active:      itemMap["active"].(bool),
+		}
+
+		if id, ok := itemMap["id"].(string); ok && id != "" {
+			parsedID, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse macro id %s: %v", id, err)
+			}
+			m.ID = parsedID
+		}
+
+		if actions, ok := itemMap["actions"].([]interface{}); ok {
+			parsedActions, err := unmarshalMacroActions(actions)
+			if err != nil {
+				return nil, err
+			}
+			m.Actions = parsedActions
+		}
+
+		macros[i] = m
+	}
+
+	return macros, nil
+}
+
+// flattenMacroSetItems converts macros back into the macro block
+// representation used by the zendesk_macro_set resource.
+func flattenMacroSetItems(macros []client.Macro) ([]map[string]interface{}, error) {
+	items := make([]map[string]interface{}, len(macros))
+	for i, macro := range macros {
+		actions := make([]map[string]interface{}, len(macro.Actions))
+		for j, action := range macro.Actions {
+			format, value, valueList, err := flattenMacroActionValue(action.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			actions[j] = map[string]interface{}{
+				"field":        action.Field,
+				"value":        value,
+				"value_list":   valueList,
+				"value_format": format,
+			}
+		}
+
+		items[i] = map[string]interface{}{
+			"id":          strconv.FormatInt(macro.ID, 10),
+			"title":       macro.Title,
+			"description": macro.Description,
+			"position":    macro.Position,
+			"active":      macro.Active,
+			"actions":     actions,
+		}
+	}
+
+	return items, nil
+}
+
+// macroSetID derives a zendesk_macro_set resource's ID from the IDs of its
+// member macros.
+func macroSetID(macros []client.Macro) string {
+	ids := make([]string, len(macros))
+	for i, macro := range macros {
+		ids[i] = strconv.FormatInt(macro.ID, 10)
+	}
+	return strings.Join(ids, ",")
+}
+
+// resourceZendeskMacroSetCreate creates every macro in a new macro set
+func resourceZendeskMacroSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zd := meta.(*client.Client)
+	return createMacroSet(ctx, d, zd)
+}
+
+// createMacroSet creates every macro in a new macro set
+func createMacroSet(ctx context.Context, d identifiableGetterSetter, zd client.MacroAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	raw, _ := d.Get("macro").([]interface{})
+	macros, err := unmarshalMacroSetItems(raw)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Zendesk has no bulk create endpoint for macros, so each macro in the
+	// set is created individually, paced by the shared rate limiter. If a
+	// later macro fails to create, the ones already created above are
+	// persisted first so a retried apply doesn't create them again.
+	created := make([]client.Macro, len(macros))
+	for i, macro := range macros {
+		if err := withMacroRateLimit(ctx, func() error {
+			var createErr error
+			created[i], createErr = zd.CreateMacro(ctx, macro)
+			return createErr
+		}); err != nil {
+			if pErr := persistMacroSet(d, created[:i]); pErr != nil {
+				return diag.FromErr(pErr)
+			}
+			return diag.FromErr(fmt.Errorf("could not create macro %q: %v", macro.Title, err))
+		}
+	}
+
+	if err := persistMacroSet(d, created); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceZendeskMacroSetRead reads every macro in a macro set
+func resourceZendeskMacroSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zd := meta.(*client.Client)
+	return readMacroSet(ctx, d, zd)
+}
+
+// readMacroSet reads every macro in a macro set
+func readMacroSet(ctx context.Context, d identifiableGetterSetter, zd client.MacroAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	macros := make([]client.Macro, 0)
+	for _, rawID := range strings.Split(d.Id(), ",") {
+		if rawID == "" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("could not parse macro id %s: %v", rawID, err))
+		}
+
+		var macro client.Macro
+		if err := withMacroRateLimit(ctx, func() error {
+			var readErr error
+			macro, readErr = zd.GetMacro(ctx, id)
+			return readErr
+		}); err != nil {
+			return diag.FromErr(err)
+		}
+
+		macros = append(macros, macro)
+	}
+
+	if err := persistMacroSet(d, macros); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceZendeskMacroSetUpdate reconciles a macro set's desired macros against its previous ones
+func resourceZendeskMacroSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zd := meta.(*client.Client)
+
+	oldRaw, newRaw := d.GetChange("macro")
+
+	oldMacros, err := unmarshalMacroSetItems(oldRaw.([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	newMacros, err := unmarshalMacroSetItems(newRaw.([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return updateMacroSet(ctx, d, zd, oldMacros, newMacros)
+}
+
+// updateMacroSet reconciles a macro set's desired macros against its
+// previous ones. The underlying client has no bulk update or delete
+// endpoints for macros, so new entries (no id yet) are created, existing
+// entries are updated, and entries dropped from the set are deleted, each
+// one macro at a time, paced by the shared rate limiter. If a create or
+// update fails, the macros already created or updated are persisted before
+// returning, so the failure can't strand an already-mutated macro out of
+// state.
+func updateMacroSet(ctx context.Context, d identifiableGetterSetter, zd client.MacroAPI, oldMacros, newMacros []client.Macro) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	result := make([]client.Macro, len(newMacros))
+	for i, macro := range newMacros {
+		if macro.ID == 0 {
+			if err := withMacroRateLimit(ctx, func() error {
+				var createErr error
+				result[i], createErr = zd.CreateMacro(ctx, macro)
+				return createErr
+			}); err != nil {
+				if pErr := persistMacroSet(d, result[:i]); pErr != nil {
+					return diag.FromErr(pErr)
+				}
+				return diag.FromErr(fmt.Errorf("could not create macro %q: %v", macro.Title, err))
+			}
+			continue
+		}
+
+		if err := withMacroRateLimit(ctx, func() error {
+			var updateErr error
+			result[i], updateErr = zd.UpdateMacro(ctx, macro.ID, macro)
+			return updateErr
+		}); err != nil {
+			if pErr := persistMacroSet(d, result[:i]); pErr != nil {
+				return diag.FromErr(pErr)
+			}
+			return diag.FromErr(fmt.Errorf("could not update macro %q: %v", macro.Title, err))
+		}
+	}
+
+	if err := persistMacroSet(d, result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, old := range oldMacros {
+		stillPresent := false
+		for _, updated := range newMacros {
+			if updated.ID == old.ID {
+				stillPresent = true
+				break
+			}
+		}
+		if stillPresent {
+			continue
+		}
+
+		if err := withMacroRateLimit(ctx, func() error {
+			return zd.DeleteMacro(ctx, old.ID)
+		}); err != nil {
+			return diag.FromErr(fmt.Errorf("could not delete macro %q: %v", old.Title, err))
+		}
+	}
+
+	return diags
+}
+
+// persistMacroSet writes the given macros' id and macro block attributes
+// into d, matching the zendesk_macro_set resource's schema.
+func persistMacroSet(d identifiableGetterSetter, macros []client.Macro) error {
+	d.SetId(macroSetID(macros))
+
+	items, err := flattenMacroSetItems(macros)
+	if err != nil {
+		return err
+	}
+
+	return setSchemaFields(d, map[string]interface{}{"macro": items})
+}
+
+// resourceZendeskMacroSetDelete deletes every macro in a macro set
+func resourceZendeskMacroSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zd := meta.(*client.Client)
+	return deleteMacroSet(ctx, d, zd)
+}
+
+// deleteMacroSet deletes every macro in a macro set, one at a time since the
+// underlying client has no bulk delete endpoint for macros.
+func deleteMacroSet(ctx context.Context, d identifiable, zd client.MacroAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, rawID := range strings.Split(d.Id(), ",") {
+		if rawID == "" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("could not parse macro id %s: %v", rawID, err))
+		}
+
+		if err := withMacroRateLimit(ctx, func() error {
+			return zd.DeleteMacro(ctx, id)
+		}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,200 @@
+package zendesk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	"github.com/nukosuke/go-zendesk/zendesk"
+	"github.com/nukosuke/go-zendesk/zendesk/mock"
+)
+
+func TestCreateMacroSet(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := &identifiableMapGetterSetter{
+		mapGetterSetter: mapGetterSetter{
+			"macro": []interface{}{
+				map[string]interface{}{
+					"title":       "first",
+					"description": "",
+					"position":    int(0),
+					"active":      true,
+					"actions": []interface{}{
+						map[string]interface{}{
+							"field": "status",
+							"value": "open",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	m.EXPECT().CreateMacro(Any(), Any()).Return(zendesk.Macro{ID: 1}, nil)
+
+	if diags := createMacroSet(context.Background(), i, m); len(diags) != 0 {
+		t.Fatalf("createMacroSet returned an error: %v", diags)
+	}
+
+	if v := i.Id(); v != "1" {
+		t.Fatalf("createMacroSet did not set resource id. Id was %s", v)
+	}
+}
+
+func TestCreateMacroSetPartialFailure(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := &identifiableMapGetterSetter{
+		mapGetterSetter: mapGetterSetter{
+			"macro": []interface{}{
+				map[string]interface{}{
+					"title":       "first",
+					"description": "",
+					"position":    int(0),
+					"active":      true,
+					"actions":     []interface{}{},
+				},
+				map[string]interface{}{
+					"title":       "second",
+					"description": "",
+					"position":    int(0),
+					"active":      true,
+					"actions":     []interface{}{},
+				},
+			},
+		},
+	}
+
+	m.EXPECT().CreateMacro(Any(), Any()).Return(zendesk.Macro{ID: 1, Title: "first"}, nil)
+	m.EXPECT().CreateMacro(Any(), Any()).Return(zendesk.Macro{}, errors.New("zendesk is down"))
+
+	if diags := createMacroSet(context.Background(), i, m); len(diags) == 0 {
+		t.Fatal("createMacroSet should have returned an error")
+	}
+
+	if v := i.Id(); v != "1" {
+		t.Fatalf("createMacroSet should have persisted the first macro created before the failure. Id was %s", v)
+	}
+
+	macros, ok := i.mapGetterSetter["macro"].([]map[string]interface{})
+	if !ok || len(macros) != 1 || macros[0]["title"] != "first" {
+		t.Fatalf("createMacroSet should have persisted only the macro created before the failure, got %v", i.mapGetterSetter["macro"])
+	}
+}
+
+func TestReadMacroSet(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := &identifiableMapGetterSetter{
+		mapGetterSetter: make(mapGetterSetter),
+		id:              "1,2",
+	}
+
+	m.EXPECT().GetMacro(Any(), Eq(int64(1))).Return(zendesk.Macro{ID: 1, Title: "first"}, nil)
+	m.EXPECT().GetMacro(Any(), Eq(int64(2))).Return(zendesk.Macro{ID: 2, Title: "second"}, nil)
+
+	if diags := readMacroSet(context.Background(), i, m); len(diags) != 0 {
+		t.Fatalf("readMacroSet returned an error: %v", diags)
+	}
+
+	macros, ok := i.mapGetterSetter["macro"].([]map[string]interface{})
+	if !ok || len(macros) != 2 {
+		t.Fatalf("macro field %v did not contain two macros", i.mapGetterSetter["macro"])
+	}
+
+	if macros[0]["title"] != "first" || macros[1]["title"] != "second" {
+		t.Fatalf("macro field %v did not preserve order", macros)
+	}
+}
+
+func TestUpdateMacroSet(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := &identifiableMapGetterSetter{
+		mapGetterSetter: make(mapGetterSetter),
+		id:              "1,2",
+	}
+
+	oldMacros := []zendesk.Macro{
+		{ID: 1, Title: "first"},
+		{ID: 2, Title: "removed"},
+	}
+	newMacros := []zendesk.Macro{
+		{ID: 1, Title: "first, renamed"},
+	}
+
+	m.EXPECT().UpdateMacro(Any(), Eq(int64(1)), Any()).Return(zendesk.Macro{ID: 1, Title: "first, renamed"}, nil)
+	m.EXPECT().DeleteMacro(Any(), Eq(int64(2))).Return(nil)
+
+	if diags := updateMacroSet(context.Background(), i, m, oldMacros, newMacros); len(diags) != 0 {
+		t.Fatalf("updateMacroSet returned an error: %v", diags)
+	}
+
+	if v := i.Id(); v != "1" {
+		t.Fatalf("updateMacroSet did not update resource id. Id was %s", v)
+	}
+}
+
+func TestUpdateMacroSetPartialFailure(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := &identifiableMapGetterSetter{
+		mapGetterSetter: make(mapGetterSetter),
+		id:              "1",
+	}
+
+	oldMacros := []zendesk.Macro{
+		{ID: 1, Title: "first"},
+	}
+	newMacros := []zendesk.Macro{
+		{ID: 1, Title: "first"},
+		{ID: 0, Title: "second"},
+	}
+
+	m.EXPECT().UpdateMacro(Any(), Eq(int64(1)), Any()).Return(zendesk.Macro{ID: 1, Title: "first"}, nil)
+	m.EXPECT().CreateMacro(Any(), Any()).Return(zendesk.Macro{}, errors.New("zendesk is down"))
+
+	if diags := updateMacroSet(context.Background(), i, m, oldMacros, newMacros); len(diags) == 0 {
+		t.Fatal("updateMacroSet should have returned an error")
+	}
+
+	// The pre-existing macro was already updated before the failed create, so
+	// it should still be persisted rather than dropped from state.
+	if v := i.Id(); v != "1" {
+		t.Fatalf("updateMacroSet should have kept the pre-existing macro in state after the failure. Id was %s", v)
+	}
+
+	macros, ok := i.mapGetterSetter["macro"].([]map[string]interface{})
+	if !ok || len(macros) != 1 || macros[0]["title"] != "first" {
+		t.Fatalf("updateMacroSet should have persisted only the pre-existing macro after the failure, got %v", i.mapGetterSetter["macro"])
+	}
+}
+
+func TestDeleteMacroSet(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := &identifiableMapGetterSetter{
+		id: "1,2",
+	}
+
+	m.EXPECT().DeleteMacro(Any(), Eq(int64(1))).Return(nil)
+	m.EXPECT().DeleteMacro(Any(), Eq(int64(2))).Return(nil)
+
+	if diags := deleteMacroSet(context.Background(), i, m); len(diags) != 0 {
+		t.Fatalf("deleteMacroSet returned an error: %v", diags)
+	}
+}
@@ -36,7 +36,10 @@ func TestReadMacro(t *testing.T) {
 		Active:      true,
 		CreatedAt:   now,
 		UpdatedAt:   now,
-		Restriction: "restriction",
+		Restriction: map[string]interface{}{
+			"type": "Group",
+			"id":   float64(360000000),
+		},
 		Actions: []zendesk.MacroAction{
 			{
 				Field: "status",
@@ -58,6 +61,10 @@ func TestReadMacro(t *testing.T) {
 		t.Fatalf("type field %v does not have expected value %v", v, field.Title)
 	}
 
+	restriction, ok := gs.mapGetterSetter["restriction"].([]map[string]interface{})
+	if !ok || len(restriction) != 1 || restriction[0]["type"] != "Group" || restriction[0]["id"] != 360000000 {
+		t.Fatalf("restriction field %v does not have expected value %v", gs.mapGetterSetter["restriction"], field.Restriction)
+	}
 }
 
 func TestDeleteMacro(t *testing.T) {
@@ -123,7 +130,12 @@ func TestMarshalMacro(t *testing.T) {
 			"description": "description",
 			"position":    int(12),
 			"active":      true,
-			"restriction": "restriction",
+			"restriction": []interface{}{
+				map[string]interface{}{
+					"type": "Group",
+					"id":   360000000,
+				},
+			},
 			"actions": []interface{}{
 				map[string]interface{}{
 					"field": "status",
@@ -138,6 +150,11 @@ func TestMarshalMacro(t *testing.T) {
 		t.Fatalf("Could not unmarshal macro: %v", err)
 	}
 
+	restriction, ok := macro.Restriction.(map[string]interface{})
+	if !ok || restriction["type"] != "Group" || restriction["id"] != 360000000 {
+		t.Fatalf("macro had incorrect restriction value %v", macro.Restriction)
+	}
+
 	if v, ok := m.Get("url").(string); !ok || macro.URL != v {
 		t.Fatalf("macro had URL value %v. should have been %v", macro.URL, v)
 	}
@@ -159,6 +176,98 @@ func TestMarshalMacro(t *testing.T) {
 	}
 }
 
+func TestResourceZendeskMacroStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"restriction": "Group",
+	}
+
+	newState, err := resourceZendeskMacroStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("resourceZendeskMacroStateUpgradeV0 returned an error: %v", err)
+	}
+
+	restriction, ok := newState["restriction"].([]interface{})
+	if !ok || len(restriction) != 1 {
+		t.Fatalf("expected restriction to be upgraded to a single-item list, got %v", newState["restriction"])
+	}
+
+	entry, ok := restriction[0].(map[string]interface{})
+	if !ok || entry["type"] != "Group" {
+		t.Fatalf("expected upgraded restriction type to be Group, got %v", restriction[0])
+	}
+}
+
+func TestResourceZendeskMacroStateUpgradeV0LegacyValue(t *testing.T) {
+	rawState := map[string]interface{}{
+		"restriction": "everyone",
+	}
+
+	newState, err := resourceZendeskMacroStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("resourceZendeskMacroStateUpgradeV0 returned an error: %v", err)
+	}
+
+	restriction, ok := newState["restriction"].([]interface{})
+	if !ok || len(restriction) != 0 {
+		t.Fatalf("expected an unrecognized legacy restriction to be dropped, got %v", newState["restriction"])
+	}
+}
+
+func TestUnmarshalMacroActionValueFormats(t *testing.T) {
+	m := &identifiableMapGetterSetter{
+		id: "1234",
+		mapGetterSetter: mapGetterSetter{
+			"title": "title",
+			"actions": []interface{}{
+				map[string]interface{}{
+					"field":        "set_tags",
+					"value_format": "list",
+					"value_list":   []interface{}{"foo", "bar"},
+				},
+				map[string]interface{}{
+					"field":        "side_conversation",
+					"value_format": "json",
+					"value":        `{"recipients":["agent@example.com"]}`,
+				},
+			},
+		},
+	}
+
+	macro, err := unmarshalMacro(m)
+	if err != nil {
+		t.Fatalf("Could not unmarshal macro: %v", err)
+	}
+
+	if v := macro.Actions[0].Value; v != `["foo","bar"]` {
+		t.Fatalf("set_tags action had incorrect value %q", v)
+	}
+
+	if v := macro.Actions[1].Value; v != `{"recipients":["agent@example.com"]}` {
+		t.Fatalf("side_conversation action had incorrect value %q", v)
+	}
+}
+
+func TestFlattenMacroActionValue(t *testing.T) {
+	format, value, list, err := flattenMacroActionValue("open")
+	if err != nil {
+		t.Fatalf("flattenMacroActionValue returned an error: %v", err)
+	}
+	if format != "string" || value != "open" || list != nil {
+		t.Fatalf("flattenMacroActionValue returned format %q value %q list %v", format, value, list)
+	}
+
+	// A value that was written via value_list or value_format = "json" still
+	// comes back as a plain string: the underlying client has no way to
+	// distinguish it from one written as a scalar.
+	format, value, _, err = flattenMacroActionValue(`["foo","bar"]`)
+	if err != nil {
+		t.Fatalf("flattenMacroActionValue returned an error: %v", err)
+	}
+	if format != "string" || value != `["foo","bar"]` {
+		t.Fatalf("flattenMacroActionValue returned format %q value %q", format, value)
+	}
+}
+
 func testMacroDestroyed(s *terraform.State) error {
 	client := testAccProvider.Meta().(zendesk.MacroAPI)
 